@@ -0,0 +1,32 @@
+package main
+
+import "gopkg.in/yaml.v3"
+
+type ConfigMap struct {
+	APIVersion string            `yaml:"APIVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   Metadata          `yaml:"metadata"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	BinaryData map[string]string `yaml:"binaryData,omitempty"`
+}
+
+// Validate implements Document.
+func (c *ConfigMap) Validate(node *yaml.Node) []Diagnostic {
+	return decodeAndValidate(node, func(src *Source) []Diagnostic {
+		var diags []Diagnostic
+
+		if c.APIVersion == "" {
+			diags = append(diags, newDiagnostic(src, []string{"APIVersion"}, codePodAPIVersion, "APIVersion is required"))
+		}
+
+		if c.Kind != "ConfigMap" {
+			diags = append(diags, newDiagnostic(src, []string{"kind"}, codePodKind, "kind must be ConfigMap"))
+		}
+
+		if len(c.Metadata.Name) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"metadata", "name"}, codeMetadataName, "name is required"))
+		}
+
+		return diags
+	})
+}
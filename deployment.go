@@ -0,0 +1,38 @@
+package main
+
+import "gopkg.in/yaml.v3"
+
+type Deployment struct {
+	APIVersion string         `yaml:"APIVersion"`
+	Kind       string         `yaml:"kind"`
+	Metadata   Metadata       `yaml:"metadata"`
+	Spec       DeploymentSpec `yaml:"spec"`
+}
+
+type DeploymentSpec struct {
+	Replicas int             `yaml:"replicas,omitempty"`
+	Template PodTemplateSpec `yaml:"template"`
+}
+
+// Validate implements Document.
+func (d *Deployment) Validate(node *yaml.Node) []Diagnostic {
+	return decodeAndValidate(node, func(src *Source) []Diagnostic {
+		var diags []Diagnostic
+
+		if d.APIVersion == "" {
+			diags = append(diags, newDiagnostic(src, []string{"APIVersion"}, codePodAPIVersion, "APIVersion is required"))
+		}
+
+		if d.Kind != "Deployment" {
+			diags = append(diags, newDiagnostic(src, []string{"kind"}, codePodKind, "kind must be Deployment"))
+		}
+
+		if len(d.Metadata.Name) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"metadata", "name"}, codeMetadataName, "name is required"))
+		}
+
+		diags = append(diags, validatePodSpec(&d.Spec.Template.Spec, src, []string{"spec", "template", "spec"})...)
+
+		return diags
+	})
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diagnostic is one validation failure, carried as structured data instead
+// of a pre-formatted string so main can render it as plain text, JSON, or
+// SARIF for CI consumption.
+type Diagnostic struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Path     string `json:"path"`
+}
+
+// Diagnostic codes. Each is stable across releases so CI systems and
+// code-review bots can key off of it (e.g. to suppress a specific check).
+const (
+	codePodAPIVersion  = "POD001"
+	codePodKind        = "POD002"
+	codeMetadataName   = "POD003"
+	codePodOS          = "POD010"
+	codeContainersReq  = "POD020"
+	codeContainerName  = "POD021"
+	codePortsRequired  = "POD022"
+	codePortRange      = "PORT001"
+	codeProbeRange     = "PROBE001"
+	codeImageInvalid   = "IMG001"
+	codeCPUQuantity    = "RES002"
+	codeMemoryQuantity = "RES003"
+	codeCPUInvariant   = "RES004"
+	codeMemInvariant   = "RES005"
+	codeServicePorts   = "SVC001"
+	codeServicePort    = "SVC002"
+	codePVCAccessModes = "PVC001"
+	codePVCStorage     = "PVC002"
+)
+
+// newDiagnostic resolves path to a line/column via src and formats a
+// Diagnostic for it.
+func newDiagnostic(src *Source, path []string, code, format string, args ...interface{}) Diagnostic {
+	line, col := src.lineCol(path...)
+	return Diagnostic{
+		File:     relPath,
+		Line:     line,
+		Column:   col,
+		Severity: "error",
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Path:     strings.Join(path, "."),
+	}
+}
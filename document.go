@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is implemented by every Kubernetes-style kind the tool knows how
+// to validate. Validate receives the yaml.Node for just this document (one
+// entry of a `---`-separated stream) and returns one Diagnostic per failed
+// check, so callers can render them as text, JSON, or SARIF. Line/Column on
+// that Node, and everything under it, are already relative to the whole
+// stream rather than to this document alone.
+type Document interface {
+	Validate(node *yaml.Node) []Diagnostic
+}
+
+// typeMeta is decoded first to sniff which concrete type a document should
+// be decoded into, mirroring how client-go picks apart a manifest.
+type typeMeta struct {
+	Kind string `yaml:"kind"`
+}
+
+// splitDocuments decodes a multi-document YAML stream into one *yaml.Node
+// per `---`-separated document, the same convention `podman kube play` and
+// `kubectl apply -f` accept. It decodes through a single yaml.Decoder over
+// the whole stream rather than splitting on literal "---" lines, so a
+// block/folded scalar that happens to contain a "---" line (e.g. an
+// embedded script in a ConfigMap's data) isn't mistaken for a document
+// separator, and every Node's line/column stays relative to the whole file.
+//
+// If a document fails to parse, splitDocuments returns the nodes decoded
+// up to that point along with the error; yaml.Decoder can't resume past a
+// syntax error, so documents after the failing one are unrecoverable.
+func splitDocuments(data []byte) ([]*yaml.Node, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var docs []*yaml.Node
+	for {
+		var node yaml.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return docs, err
+		}
+
+		if isEmptyDocument(&node) {
+			continue
+		}
+		docs = append(docs, &node)
+	}
+}
+
+// isEmptyDocument reports whether node is the implicit null document a
+// stray "---" (e.g. two separators with nothing between them) decodes to,
+// as opposed to an actual manifest.
+func isEmptyDocument(node *yaml.Node) bool {
+	if len(node.Content) != 1 {
+		return false
+	}
+	content := node.Content[0]
+	return content.Kind == yaml.ScalarNode && content.Tag == "!!null" && content.Value == ""
+}
+
+// decodeAndValidate wraps node in a Source and runs validate against it, so
+// every Document implementation's Validate method is just this plus its own
+// validate function, and kinds don't each re-paste the Source boilerplate.
+func decodeAndValidate(node *yaml.Node, validate func(src *Source) []Diagnostic) []Diagnostic {
+	return validate(newSource(node))
+}
+
+// decodeDocument sniffs `kind` and decodes node into the matching Document
+// implementation.
+func decodeDocument(node *yaml.Node) (Document, error) {
+	var meta typeMeta
+	if err := node.Decode(&meta); err != nil {
+		return nil, fmt.Errorf("cannot decode document: %w", err)
+	}
+
+	var doc Document
+	switch meta.Kind {
+	case "Pod":
+		doc = &Pod{}
+	case "Deployment":
+		doc = &Deployment{}
+	case "Service":
+		doc = &Service{}
+	case "ConfigMap":
+		doc = &ConfigMap{}
+	case "PersistentVolumeClaim":
+		doc = &PersistentVolumeClaim{}
+	default:
+		return nil, fmt.Errorf("unsupported kind %q", meta.Kind)
+	}
+
+	if err := node.Decode(doc); err != nil {
+		return nil, fmt.Errorf("cannot decode document: %w", err)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import "testing"
+
+func TestSplitDocumentsLineIsRelativeToWholeStream(t *testing.T) {
+	data := []byte("kind: Pod\nmetadata:\n  name: a\n---\nkind: Pod\nmetadata:\n  name: b\n")
+
+	docs, err := splitDocuments(data)
+	if err != nil {
+		t.Fatalf("splitDocuments: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("splitDocuments returned %d documents, want 2", len(docs))
+	}
+	if line, _ := newSource(docs[0]).lineCol("kind"); line != 1 {
+		t.Errorf("docs[0] kind line = %d, want 1", line)
+	}
+	if line, _ := newSource(docs[1]).lineCol("kind"); line != 5 {
+		t.Errorf("docs[1] kind line = %d, want 5", line)
+	}
+}
+
+// TestSplitDocumentsIgnoresSeparatorInsideBlockScalar guards against
+// resurrecting the old line-based splitter, which mistook a "---" line
+// inside a block/folded scalar (e.g. an embedded script in a ConfigMap's
+// data) for a document boundary.
+func TestSplitDocumentsIgnoresSeparatorInsideBlockScalar(t *testing.T) {
+	data := []byte("kind: ConfigMap\n" +
+		"metadata:\n" +
+		"  name: a\n" +
+		"data:\n" +
+		"  script: |\n" +
+		"    echo hi\n" +
+		"    ---\n" +
+		"    echo bye\n")
+
+	docs, err := splitDocuments(data)
+	if err != nil {
+		t.Fatalf("splitDocuments: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("splitDocuments returned %d documents, want 1", len(docs))
+	}
+
+	var cm ConfigMap
+	if err := docs[0].Decode(&cm); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := "echo hi\n---\necho bye\n"
+	if cm.Data["script"] != want {
+		t.Errorf("data.script = %q, want %q", cm.Data["script"], want)
+	}
+}
+
+func TestSplitDocumentsSkipsEmptyDocuments(t *testing.T) {
+	data := []byte("---\nkind: Pod\nmetadata:\n  name: a\n---\n---\n")
+
+	docs, err := splitDocuments(data)
+	if err != nil {
+		t.Fatalf("splitDocuments: %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("splitDocuments returned %d documents, want 1", len(docs))
+	}
+}
+
+func TestSplitDocumentsStopsAtFirstSyntaxError(t *testing.T) {
+	data := []byte("kind: Pod\nmetadata:\n  name: a\n---\nkind: [unterminated\n")
+
+	docs, err := splitDocuments(data)
+	if err == nil {
+		t.Fatal("splitDocuments: want error for malformed second document")
+	}
+	if len(docs) != 1 {
+		t.Fatalf("splitDocuments returned %d documents before the error, want 1", len(docs))
+	}
+}
+
+func TestSourceLineColNoOffsetOnNilSource(t *testing.T) {
+	var src *Source
+
+	line, col := src.lineCol("spec", "containers")
+	if line != -1 || col != -1 {
+		t.Fatalf("lineCol on a nil Source = (%d, %d), want (-1, -1)", line, col)
+	}
+}
+
+func TestPodValidateReportsLineRelativeToWholeStream(t *testing.T) {
+	relPath = "multi.yaml"
+	data := []byte("kind: Pod\nmetadata:\n  name: a\n---\nkind: Pod\nmetadata:\n  name: b\n")
+
+	docs, err := splitDocuments(data)
+	if err != nil {
+		t.Fatalf("splitDocuments: %v", err)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("splitDocuments returned %d documents, want 2", len(docs))
+	}
+
+	pod := &Pod{}
+	diags := pod.Validate(docs[1])
+
+	var apiVersionLine int
+	found := false
+	for _, d := range diags {
+		if d.Code == codePodAPIVersion {
+			apiVersionLine = d.Line
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a %s diagnostic, got %v", codePodAPIVersion, diags)
+	}
+	if apiVersionLine != 5 {
+		t.Fatalf("%s diagnostic line = %d, want 5", codePodAPIVersion, apiVersionLine)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// labelFlag accumulates repeated `--label k=v` flags into a map, the same
+// way kubectl/podman CLIs handle repeatable key-value flags.
+type labelFlag struct {
+	values map[string]string
+}
+
+func (l *labelFlag) String() string {
+	return fmt.Sprint(l.values)
+}
+
+func (l *labelFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("label %q must be in k=v form", s)
+	}
+	if l.values == nil {
+		l.values = make(map[string]string)
+	}
+	l.values[key] = value
+	return nil
+}
+
+// runGenerate builds a Pod from CLI flags and prints it as YAML, analogous
+// to `podman kube generate`. It refuses to print anything validatePod would
+// reject, so the tool can never hand out YAML it would itself flag as
+// invalid.
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	name := fs.String("name", "", "pod name")
+	image := fs.String("image", "", "container image")
+	port := fs.Int("port", 0, "container port")
+	cpuRequest := fs.String("cpu-request", "", "cpu request, e.g. 500m")
+	memoryLimit := fs.String("memory-limit", "", "memory limit, e.g. 256Mi")
+	livenessHTTP := fs.String("liveness-http", "", "liveness probe HTTP GET path, e.g. /healthz")
+	readinessHTTP := fs.String("readiness-http", "", "readiness probe HTTP GET path, e.g. /ready")
+	osName := fs.String("os", "linux", "pod os (linux or windows)")
+	var labels labelFlag
+	fs.Var(&labels, "label", "pod label in k=v form (repeatable)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pod := buildGeneratedPod(generateOptions{
+		name:          *name,
+		image:         *image,
+		port:          *port,
+		cpuRequest:    *cpuRequest,
+		memoryLimit:   *memoryLimit,
+		livenessHTTP:  *livenessHTTP,
+		readinessHTTP: *readinessHTTP,
+		os:            *osName,
+		labels:        labels.values,
+	})
+
+	out, err := yaml.Marshal(&pod)
+	if err != nil {
+		return fmt.Errorf("cannot marshal generated pod: %w", err)
+	}
+
+	relPath = "generated"
+	docs, err := splitDocuments(out)
+	if err != nil || len(docs) != 1 {
+		return fmt.Errorf("cannot parse generated pod back into a document: %w", err)
+	}
+	if diags := pod.Validate(docs[0]); len(diags) > 0 {
+		return fmt.Errorf("generated pod failed its own validation:\n%w", joinDiagnostics(diags))
+	}
+
+	fmt.Fprint(os.Stdout, string(out))
+	return nil
+}
+
+type generateOptions struct {
+	name          string
+	image         string
+	port          int
+	cpuRequest    string
+	memoryLimit   string
+	livenessHTTP  string
+	readinessHTTP string
+	os            string
+	labels        map[string]string
+}
+
+func buildGeneratedPod(opts generateOptions) Pod {
+	container := Container{
+		Name:  opts.name,
+		Image: opts.image,
+	}
+
+	if opts.port > 0 {
+		container.Ports = []Port{{ContainerPort: opts.port, Protocol: "TCP"}}
+	}
+
+	if opts.cpuRequest != "" {
+		container.Resources.Requests.CPU = opts.cpuRequest
+	}
+	if opts.memoryLimit != "" {
+		container.Resources.Limits.Memory = opts.memoryLimit
+	}
+
+	if opts.livenessHTTP != "" {
+		container.LivenessProbe = Probe{HTTPGet: HTTPGet{Path: opts.livenessHTTP, Port: opts.port}}
+	}
+	if opts.readinessHTTP != "" {
+		container.ReadinessProbe = Probe{HTTPGet: HTTPGet{Path: opts.readinessHTTP, Port: opts.port}}
+	}
+
+	return Pod{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Metadata: Metadata{
+			Name:   opts.name,
+			Labels: opts.labels,
+		},
+		Spec: Spec{
+			OS:         opts.os,
+			Containers: []Container{container},
+		},
+	}
+}
+
+func joinDiagnostics(diags []Diagnostic) error {
+	msgs := make([]string, len(diags))
+	for i, d := range diags {
+		msgs[i] = fmt.Sprintf("%s:%d:%d: [%s] %s", d.File, d.Line, d.Column, d.Code, d.Message)
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func TestBuildGeneratedPod(t *testing.T) {
+	pod := buildGeneratedPod(generateOptions{
+		name:          "web",
+		image:         "nginx:1.27",
+		port:          8080,
+		cpuRequest:    "500m",
+		memoryLimit:   "256Mi",
+		livenessHTTP:  "/healthz",
+		readinessHTTP: "/ready",
+		os:            "linux",
+		labels:        map[string]string{"app": "web"},
+	})
+
+	if pod.APIVersion != "v1" || pod.Kind != "Pod" {
+		t.Fatalf("pod.APIVersion/Kind = %s/%s, want v1/Pod", pod.APIVersion, pod.Kind)
+	}
+	if pod.Metadata.Name != "web" || pod.Metadata.Labels["app"] != "web" {
+		t.Fatalf("pod.Metadata = %+v, want name web and label app=web", pod.Metadata)
+	}
+	if len(pod.Spec.Containers) != 1 {
+		t.Fatalf("len(pod.Spec.Containers) = %d, want 1", len(pod.Spec.Containers))
+	}
+
+	container := pod.Spec.Containers[0]
+	if container.Image != "nginx:1.27" {
+		t.Errorf("container.Image = %q, want nginx:1.27", container.Image)
+	}
+	if len(container.Ports) != 1 || container.Ports[0].ContainerPort != 8080 {
+		t.Errorf("container.Ports = %+v, want one port 8080", container.Ports)
+	}
+	if container.Resources.Requests.CPU != "500m" {
+		t.Errorf("container.Resources.Requests.CPU = %v, want 500m", container.Resources.Requests.CPU)
+	}
+	if container.Resources.Limits.Memory != "256Mi" {
+		t.Errorf("container.Resources.Limits.Memory = %q, want 256Mi", container.Resources.Limits.Memory)
+	}
+	if container.LivenessProbe.HTTPGet.Path != "/healthz" || container.LivenessProbe.HTTPGet.Port != 8080 {
+		t.Errorf("container.LivenessProbe = %+v, want /healthz on port 8080", container.LivenessProbe)
+	}
+	if container.ReadinessProbe.HTTPGet.Path != "/ready" || container.ReadinessProbe.HTTPGet.Port != 8080 {
+		t.Errorf("container.ReadinessProbe = %+v, want /ready on port 8080", container.ReadinessProbe)
+	}
+}
+
+func TestBuildGeneratedPodOmitsUnconfiguredFields(t *testing.T) {
+	pod := buildGeneratedPod(generateOptions{
+		name:  "web",
+		image: "nginx",
+		os:    "linux",
+	})
+
+	container := pod.Spec.Containers[0]
+	if len(container.Ports) != 0 {
+		t.Errorf("container.Ports = %v, want none when no port flag is set", container.Ports)
+	}
+	if container.LivenessProbe != (Probe{}) {
+		t.Errorf("container.LivenessProbe = %+v, want zero value when not configured", container.LivenessProbe)
+	}
+	if container.ReadinessProbe != (Probe{}) {
+		t.Errorf("container.ReadinessProbe = %+v, want zero value when not configured", container.ReadinessProbe)
+	}
+}
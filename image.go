@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	imageComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*$`)
+	imageTagPattern       = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	imageDigestPattern    = regexp.MustCompile(`^([a-z0-9]+):([a-f0-9]+)$`)
+)
+
+// validateImageReference checks image against the distribution/OCI
+// reference grammar:
+//
+//	[registry[:port]/][namespace/]name[:tag][@digest]
+//
+// When strict is true, the common policy of rejecting the floating
+// "latest" tag is also enforced.
+func validateImageReference(image string, strict bool) error {
+	if image == "" {
+		return errors.New("is required")
+	}
+
+	ref := image
+
+	if idx := strings.Index(ref, "@"); idx != -1 {
+		digest := ref[idx+1:]
+		ref = ref[:idx]
+		if err := validateImageDigest(digest); err != nil {
+			return err
+		}
+	}
+
+	lastSlash := strings.LastIndex(ref, "/")
+	lastSegment := ref
+	prefix := ""
+	if lastSlash != -1 {
+		lastSegment = ref[lastSlash+1:]
+		prefix = ref[:lastSlash]
+	}
+
+	if idx := strings.LastIndex(lastSegment, ":"); idx != -1 {
+		tag := lastSegment[idx+1:]
+		if !imageTagPattern.MatchString(tag) {
+			return fmt.Errorf("has invalid tag %q", tag)
+		}
+		if strict && tag == "latest" {
+			return errors.New("must not use the 'latest' tag with --strict")
+		}
+		lastSegment = lastSegment[:idx]
+	}
+
+	if prefix != "" {
+		ref = prefix + "/" + lastSegment
+	} else {
+		ref = lastSegment
+	}
+
+	components := strings.Split(ref, "/")
+	if len(components) > 1 {
+		first := components[0]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			components = components[1:] // registry[:port]
+		}
+	}
+
+	if len(components) == 0 || components[0] == "" {
+		return errors.New("name is required")
+	}
+
+	for _, component := range components {
+		if !imageComponentPattern.MatchString(component) {
+			return fmt.Errorf("has invalid name component %q", component)
+		}
+	}
+
+	return nil
+}
+
+func validateImageDigest(digest string) error {
+	matches := imageDigestPattern.FindStringSubmatch(digest)
+	if matches == nil {
+		return fmt.Errorf("has invalid digest %q", digest)
+	}
+
+	algorithm, hex := matches[1], matches[2]
+	if algorithm == "sha256" && len(hex) != 64 {
+		return fmt.Errorf("has invalid sha256 digest %q: want 64 hex characters", digest)
+	}
+
+	return nil
+}
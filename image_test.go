@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateImageReference(t *testing.T) {
+	sha256Digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := []struct {
+		name    string
+		image   string
+		strict  bool
+		wantErr bool
+	}{
+		{name: "name only", image: "nginx"},
+		{name: "name and tag", image: "nginx:1.27"},
+		{name: "namespaced", image: "library/nginx:1.27"},
+		{name: "registry with port", image: "localhost:5000/app:1.0"},
+		{name: "registry with port and namespace", image: "registry.example.com:5000/team/app:1.0"},
+		{name: "digest", image: "nginx@" + sha256Digest},
+		{name: "tag and digest", image: "nginx:1.27@" + sha256Digest},
+		{name: "latest tag without strict", image: "nginx:latest", strict: false},
+		{name: "latest tag with strict", image: "nginx:latest", strict: true, wantErr: true},
+		{name: "empty", image: "", wantErr: true},
+		{name: "invalid name component", image: "NGINX", wantErr: true},
+		{name: "invalid tag", image: "nginx:!bad", wantErr: true},
+		{name: "invalid digest algorithm case", image: "nginx@SHA256:" + strings.Repeat("a", 64), wantErr: true},
+		{name: "short sha256 digest", image: "nginx@sha256:abc123", wantErr: true},
+		{name: "malformed digest", image: "nginx@not-a-digest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateImageReference(tt.image, tt.strict)
+			if tt.wantErr && err == nil {
+				t.Fatalf("validateImageReference(%q, %v) = nil, want error", tt.image, tt.strict)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validateImageReference(%q, %v) returned unexpected error: %v", tt.image, tt.strict, err)
+			}
+		})
+	}
+}
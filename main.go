@@ -2,85 +2,75 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
-
-	"gopkg.in/yaml.v3"
 )
 
 var (
 	absPath string
 	relPath string
-)
-
-type Pod struct {
-	APIVersion string   `yaml:"APIVersion"`
-	Kind       string   `yaml:"kind"`
-	Metadata   Metadata `yaml:"metadata"`
-	Spec       Spec     `yaml:"spec"`
-}
 
-type Metadata struct {
-	Name      string            `yaml:"name"`
-	Namespace string            `yaml:"namespace,omitempty"`
-	Labels    map[string]string `yaml:"labels,omitempty"`
-}
+	// strictMode enables rejection of common policy violations, such as an
+	// image reference pinned to the floating "latest" tag, that are legal
+	// Kubernetes YAML but generally discouraged.
+	strictMode bool
+)
 
-type Spec struct {
-	OS         string      `yaml:"os"`
-	Containers []Container `yaml:"containers"`
-}
+func main() {
+	args := os.Args[1:]
 
-type Container struct {
-	Name           string   `yaml:"name"`
-	Image          string   `yaml:"image"`
-	Ports          []Port   `yaml:"ports,omitempty"`
-	ReadinessProbe Probe    `yaml:"readinessProbe,omitempty"`
-	LivenessProbe  Probe    `yaml:"livenessProbe,omitempty"`
-	Resources      Resource `yaml:"resources,omitempty"`
-}
+	if len(args) > 0 && args[0] == "generate" {
+		if err := runGenerate(args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-type Port struct {
-	ContainerPort int    `yaml:"containerPort"`
-	Protocol      string `yaml:"protocol,omitempty"`
-}
+	// "validate" is the default subcommand, so `tool file.yaml` and
+	// `tool validate file.yaml` are equivalent.
+	if len(args) > 0 && args[0] == "validate" {
+		args = args[1:]
+	}
 
-type Probe struct {
-	HTTPGet HTTPGet `yaml:"httpGet,omitempty"`
-}
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.BoolVar(&strictMode, "strict", false, "reject common policy violations, such as the 'latest' image tag")
+	format := fs.String("format", "text", "diagnostic output format: text, json, or sarif")
+	fs.Parse(args)
 
-type HTTPGet struct {
-	Path string `yaml:"path"`
-	Port int    `yaml:"port"`
-}
+	positional := fs.Args()
+	if len(positional) != 1 {
+		fmt.Fprintln(os.Stderr, "path to yaml is not provided")
+		os.Exit(1)
+	}
 
-type Resource struct {
-	Limits   ResourceLimits `yaml:"limits,omitempty"`
-	Requests ResourceLimits `yaml:"requests,omitempty"`
-}
+	if err := setPaths(positional[0]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
-type ResourceLimits struct {
-	CPU    interface{} `yaml:"cpu,omitempty"`
-	Memory string      `yaml:"memory,omitempty"`
+	runValidate(*format)
 }
 
-func init() {
-	if len(os.Args[1:]) != 1 {
-		panic("path to yaml is not provided")
+func setPaths(filePath string) error {
+	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s does not exist", filePath)
 	}
-	filePath := os.Args[1]
-	_, err := os.Stat(filePath)
-	if errors.Is(err, os.ErrNotExist) {
-		panic(fmt.Sprintf("%s does not exist", filePath))
+
+	var err error
+	absPath, err = filepath.Abs(filePath)
+	if err != nil {
+		return err
 	}
-	absPath, _ = filepath.Abs(filePath)
+
 	parentDir := filepath.Dir(filePath)
-	relPath, _ = filepath.Rel(parentDir, filePath)
+	relPath, err = filepath.Rel(parentDir, filePath)
+	return err
 }
 
-func main() {
+func runValidate(format string) {
 	// Read the YAML file content
 	data, err := os.ReadFile(absPath)
 	if err != nil {
@@ -88,141 +78,66 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Unmarshal the YAML content into the Pod struct
-	var pod Pod
-	err = yaml.Unmarshal(data, &pod)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "cannot unmarshal file content: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Validate the Pod struct
-	err = validatePod(&pod, data)
-	if err != nil {
-		fmt.Fprintf(os.Stdout, "%v\n", err)
+	docs, splitErr := splitDocuments(data)
+	if len(docs) == 0 && splitErr == nil {
+		fmt.Fprintf(os.Stderr, "%s: no YAML documents found\n", relPath)
 		os.Exit(1)
 	}
-}
-
-func validatePod(pod *Pod, data []byte) error {
-	var validationErrors []string
-
-	// Validate APIVersion
-	if pod.APIVersion != "v1" {
-		validationErrors = append(validationErrors, fmt.Sprintf("%s: APIVersion must be v1", relPath))
-	}
-
-	// Validate kind
-	if pod.Kind != "Pod" {
-		validationErrors = append(validationErrors, fmt.Sprintf("%s: kind must be Pod", relPath))
-	}
-
-	// Validate metadata.name
-	if len(pod.Metadata.Name) == 0 {
-		line := getLineNumber(data, "name")
-		validationErrors = append(validationErrors, fmt.Sprintf("%s:%d: name is required", relPath, line))
-	}
-
-	// Validate spec.os
-	validOSValues := map[string]bool{"linux": true, "windows": true}
-	if !validOSValues[pod.Spec.OS] {
-		line := getLineNumber(data, "os")
-		validationErrors = append(validationErrors, fmt.Sprintf("%s:%d: os has unsupported value '%s'", relPath, line, pod.Spec.OS))
-	}
-
-	// Validate containers
-	if len(pod.Spec.Containers) == 0 {
-		validationErrors = append(validationErrors, fmt.Sprintf("%s: spec.containers is required", relPath))
-	}
-
-	for _, container := range pod.Spec.Containers {
-		// Validate container name
-		if strings.TrimSpace(container.Name) == "" {
-			line := getLineNumber(data, "name")
-			validationErrors = append(validationErrors, fmt.Sprintf("%s:%d: name is required", relPath, line))
-		}
-
-		// Validate container image
-		if container.Image == "" {
-			validationErrors = append(validationErrors, fmt.Sprintf("%s: container.image is required", relPath))
-		}
-
-		// Validate container ports
-		if len(container.Ports) == 0 {
-			validationErrors = append(validationErrors, fmt.Sprintf("%s: container must define at least one port", relPath))
-		}
-
-		for _, port := range container.Ports {
-			if err := validatePort(port, data); err != nil {
-				validationErrors = append(validationErrors, err.Error())
-			}
-		}
 
-		// Validate readiness and liveness probes
-		if err := validateProbe(container.ReadinessProbe, "readinessProbe", data); err != nil {
-			validationErrors = append(validationErrors, err.Error())
-		}
-		if err := validateProbe(container.LivenessProbe, "livenessProbe", data); err != nil {
-			validationErrors = append(validationErrors, err.Error())
+	var diags []Diagnostic
+	for i, node := range docs {
+		document, err := decodeDocument(node)
+		if err != nil {
+			diags = append(diags, Diagnostic{
+				File:     relPath,
+				Line:     node.Line,
+				Column:   node.Column,
+				Severity: "error",
+				Code:     "DOC001",
+				Message:  err.Error(),
+				Path:     fmt.Sprintf("document[%d]", i),
+			})
+			continue
 		}
 
-		// Validate resources
-		if err := validateResources(container.Resources, data); err != nil {
-			validationErrors = append(validationErrors, err.Error())
+		for _, d := range document.Validate(node) {
+			d.Path = fmt.Sprintf("document[%d].%s", i, d.Path)
+			diags = append(diags, d)
 		}
 	}
 
-	// Return all validation errors if any
-	if len(validationErrors) > 0 {
-		return errors.New(strings.Join(validationErrors, "\n"))
-	}
-
-	return nil
-}
-
-func validatePort(port Port, data []byte) error {
-	// Validate container port range
-	if port.ContainerPort <= 0 || port.ContainerPort > 65535 {
-		line := getLineNumber(data, "containerPort")
-		return fmt.Errorf("%s:%d: containerPort value out of range", relPath, line)
+	if splitErr != nil {
+		diags = append(diags, Diagnostic{
+			File:     relPath,
+			Line:     -1,
+			Column:   -1,
+			Severity: "error",
+			Code:     "DOC001",
+			Message:  splitErr.Error(),
+			Path:     fmt.Sprintf("document[%d]", len(docs)),
+		})
 	}
 
-	return nil
-}
-
-func validateProbe(probe Probe, probeType string, data []byte) error {
-	if probe.HTTPGet.Port <= 0 || probe.HTTPGet.Port > 65535 {
-		line := getLineNumber(data, "port")
-		return fmt.Errorf("%s:%d port value out of range", relPath, line)
+	if err := renderDiagnostics(os.Stdout, format, diags); err != nil {
+		fmt.Fprintf(os.Stderr, "cannot render diagnostics: %v\n", err)
+		os.Exit(1)
 	}
-	return nil
-}
 
-func validateResources(resources Resource, data []byte) error {
-	if resources.Requests.CPU != "" {
-		if _, err := validateCPU(resources.Requests.CPU); err != nil {
-			line := getLineNumber(data, "cpu")
-			return fmt.Errorf("%s:%d: cpu %s", relPath, line, err.Error())
-		}
+	if len(diags) > 0 {
+		os.Exit(1)
 	}
-	return nil
 }
 
-func validateCPU(cpu interface{}) (int, error) {
-	switch cpu := cpu.(type) {
-	case int:
-		return cpu, nil
+func renderDiagnostics(w *os.File, format string, diags []Diagnostic) error {
+	switch format {
+	case "json":
+		return renderJSON(w, diags)
+	case "sarif":
+		return renderSARIF(w, diags)
+	case "text", "":
+		renderText(w, diags)
+		return nil
 	default:
-		return 0, errors.New("must be int")
-	}
-}
-
-func getLineNumber(data []byte, field string) int {
-	lines := strings.Split(string(data), "\n")
-	for i, line := range lines {
-		if strings.Contains(line, field) {
-			return i + 1 // +1 for 1-based index
-		}
+		return fmt.Errorf("unsupported format %q", format)
 	}
-	return -1 // Return -1 if field is
 }
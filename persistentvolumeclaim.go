@@ -0,0 +1,52 @@
+package main
+
+import "gopkg.in/yaml.v3"
+
+type PersistentVolumeClaim struct {
+	APIVersion string   `yaml:"APIVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       PVCSpec  `yaml:"spec"`
+}
+
+type PVCSpec struct {
+	AccessModes []string     `yaml:"accessModes"`
+	Resources   PVCResources `yaml:"resources"`
+}
+
+type PVCResources struct {
+	Requests PVCResourceList `yaml:"requests"`
+}
+
+type PVCResourceList struct {
+	Storage string `yaml:"storage"`
+}
+
+// Validate implements Document.
+func (p *PersistentVolumeClaim) Validate(node *yaml.Node) []Diagnostic {
+	return decodeAndValidate(node, func(src *Source) []Diagnostic {
+		var diags []Diagnostic
+
+		if p.APIVersion == "" {
+			diags = append(diags, newDiagnostic(src, []string{"APIVersion"}, codePodAPIVersion, "APIVersion is required"))
+		}
+
+		if p.Kind != "PersistentVolumeClaim" {
+			diags = append(diags, newDiagnostic(src, []string{"kind"}, codePodKind, "kind must be PersistentVolumeClaim"))
+		}
+
+		if len(p.Metadata.Name) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"metadata", "name"}, codeMetadataName, "name is required"))
+		}
+
+		if len(p.Spec.AccessModes) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"spec"}, codePVCAccessModes, "spec.accessModes is required"))
+		}
+
+		if p.Spec.Resources.Requests.Storage == "" {
+			diags = append(diags, newDiagnostic(src, []string{"spec", "resources"}, codePVCStorage, "spec.resources.requests.storage is required"))
+		}
+
+		return diags
+	})
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Pod struct {
+	APIVersion string   `yaml:"APIVersion"`
+	Kind       string   `yaml:"kind"`
+	Metadata   Metadata `yaml:"metadata"`
+	Spec       Spec     `yaml:"spec"`
+}
+
+type Metadata struct {
+	Name      string            `yaml:"name"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+// PodTemplateSpec is the pod template embedded by higher-level kinds such as
+// Deployment, letting them reuse the same container/probe/port validation as
+// a bare Pod.
+type PodTemplateSpec struct {
+	Metadata Metadata `yaml:"metadata,omitempty"`
+	Spec     Spec     `yaml:"spec"`
+}
+
+type Spec struct {
+	OS         string      `yaml:"os"`
+	Containers []Container `yaml:"containers"`
+}
+
+type Container struct {
+	Name           string   `yaml:"name"`
+	Image          string   `yaml:"image"`
+	Ports          []Port   `yaml:"ports,omitempty"`
+	ReadinessProbe Probe    `yaml:"readinessProbe,omitempty"`
+	LivenessProbe  Probe    `yaml:"livenessProbe,omitempty"`
+	Resources      Resource `yaml:"resources,omitempty"`
+}
+
+type Port struct {
+	ContainerPort int    `yaml:"containerPort"`
+	Protocol      string `yaml:"protocol,omitempty"`
+}
+
+type Probe struct {
+	HTTPGet HTTPGet `yaml:"httpGet,omitempty"`
+}
+
+type HTTPGet struct {
+	Path string `yaml:"path"`
+	Port int    `yaml:"port"`
+}
+
+type Resource struct {
+	Limits   ResourceLimits `yaml:"limits,omitempty"`
+	Requests ResourceLimits `yaml:"requests,omitempty"`
+}
+
+type ResourceLimits struct {
+	CPU    interface{} `yaml:"cpu,omitempty"`
+	Memory string      `yaml:"memory,omitempty"`
+}
+
+// Validate implements Document.
+func (pod *Pod) Validate(node *yaml.Node) []Diagnostic {
+	return decodeAndValidate(node, func(src *Source) []Diagnostic {
+		return validatePod(pod, src)
+	})
+}
+
+func validatePod(pod *Pod, src *Source) []Diagnostic {
+	var diags []Diagnostic
+
+	if pod.APIVersion != "v1" {
+		diags = append(diags, newDiagnostic(src, []string{"APIVersion"}, codePodAPIVersion, "APIVersion must be v1"))
+	}
+
+	if pod.Kind != "Pod" {
+		diags = append(diags, newDiagnostic(src, []string{"kind"}, codePodKind, "kind must be Pod"))
+	}
+
+	if len(pod.Metadata.Name) == 0 {
+		diags = append(diags, newDiagnostic(src, []string{"metadata", "name"}, codeMetadataName, "name is required"))
+	}
+
+	validOSValues := map[string]bool{"linux": true, "windows": true}
+	if !validOSValues[pod.Spec.OS] {
+		diags = append(diags, newDiagnostic(src, []string{"spec", "os"}, codePodOS, "os has unsupported value '%s'", pod.Spec.OS))
+	}
+
+	diags = append(diags, validatePodSpec(&pod.Spec, src, []string{"spec"})...)
+
+	return diags
+}
+
+// validatePodSpec validates the part of a Pod shared with PodTemplateSpec
+// (i.e. everything under spec.containers), so Deployment and friends can
+// reuse it without re-validating APIVersion/kind/metadata.name. specPath is
+// the path to spec within the document (e.g. ["spec"] for a Pod, or
+// ["spec", "template", "spec"] for a Deployment).
+func validatePodSpec(spec *Spec, src *Source, specPath []string) []Diagnostic {
+	var diags []Diagnostic
+
+	containersPath := joinPath(specPath, "containers")
+
+	if len(spec.Containers) == 0 {
+		diags = append(diags, newDiagnostic(src, specPath, codeContainersReq, "spec.containers is required"))
+	}
+
+	for i, container := range spec.Containers {
+		containerPath := joinPath(containersPath, strconv.Itoa(i))
+
+		if strings.TrimSpace(container.Name) == "" {
+			diags = append(diags, newDiagnostic(src, joinPath(containerPath, "name"), codeContainerName, "name is required"))
+		}
+
+		if err := validateImageReference(container.Image, strictMode); err != nil {
+			diags = append(diags, newDiagnostic(src, joinPath(containerPath, "image"), codeImageInvalid, "image %s", err.Error()))
+		}
+
+		if len(container.Ports) == 0 {
+			diags = append(diags, newDiagnostic(src, containerPath, codePortsRequired, "container must define at least one port"))
+		}
+
+		portsPath := joinPath(containerPath, "ports")
+		for j, port := range container.Ports {
+			diags = append(diags, validatePort(port, src, joinPath(portsPath, strconv.Itoa(j)))...)
+		}
+
+		diags = append(diags, validateProbe(container.ReadinessProbe, src, joinPath(containerPath, "readinessProbe"))...)
+		diags = append(diags, validateProbe(container.LivenessProbe, src, joinPath(containerPath, "livenessProbe"))...)
+
+		diags = append(diags, validateResources(container.Resources, src, joinPath(containerPath, "resources"))...)
+	}
+
+	return diags
+}
+
+func validatePort(port Port, src *Source, portPath []string) []Diagnostic {
+	if port.ContainerPort <= 0 || port.ContainerPort > 65535 {
+		return []Diagnostic{newDiagnostic(src, joinPath(portPath, "containerPort"), codePortRange, "containerPort value out of range")}
+	}
+	return nil
+}
+
+func validateProbe(probe Probe, src *Source, probePath []string) []Diagnostic {
+	if probe.HTTPGet.Path == "" && probe.HTTPGet.Port == 0 {
+		// Readiness/liveness probes are optional; a probe that was never
+		// configured has nothing to validate.
+		return nil
+	}
+	if probe.HTTPGet.Port <= 0 || probe.HTTPGet.Port > 65535 {
+		return []Diagnostic{newDiagnostic(src, joinPath(probePath, "httpGet", "port"), codeProbeRange, "port value out of range")}
+	}
+	return nil
+}
+
+func validateResources(resources Resource, src *Source, resourcesPath []string) []Diagnostic {
+	var diags []Diagnostic
+
+	limitsPath := joinPath(resourcesPath, "limits")
+	requestsPath := joinPath(resourcesPath, "requests")
+
+	limitCPU, limitCPUOK := validateResourceCPU(resources.Limits.CPU, src, joinPath(limitsPath, "cpu"), &diags)
+	requestCPU, requestCPUOK := validateResourceCPU(resources.Requests.CPU, src, joinPath(requestsPath, "cpu"), &diags)
+	if limitCPUOK && requestCPUOK && requestCPU > limitCPU {
+		requestStr, _ := quantityString(resources.Requests.CPU)
+		limitStr, _ := quantityString(resources.Limits.CPU)
+		diags = append(diags, newDiagnostic(src, joinPath(requestsPath, "cpu"), codeCPUInvariant, "cpu requests (%s) must not exceed limits (%s)", requestStr, limitStr))
+	}
+
+	limitMemory, limitMemoryOK := validateResourceMemory(resources.Limits.Memory, src, joinPath(limitsPath, "memory"), &diags)
+	requestMemory, requestMemoryOK := validateResourceMemory(resources.Requests.Memory, src, joinPath(requestsPath, "memory"), &diags)
+	if limitMemoryOK && requestMemoryOK && requestMemory > limitMemory {
+		diags = append(diags, newDiagnostic(src, joinPath(requestsPath, "memory"), codeMemInvariant, "memory requests (%s) must not exceed limits (%s)", resources.Requests.Memory, resources.Limits.Memory))
+	}
+
+	return diags
+}
+
+// validateResourceCPU parses a limits/requests.cpu field, appending any
+// parse diagnostic to diags, and reports whether the returned millicore
+// value is usable for the requests<=limits comparison.
+func validateResourceCPU(cpu interface{}, src *Source, cpuPath []string, diags *[]Diagnostic) (int64, bool) {
+	raw, ok := quantityString(cpu)
+	if !ok {
+		return 0, false
+	}
+	millicores, err := validateCPU(raw)
+	if err != nil {
+		*diags = append(*diags, newDiagnostic(src, cpuPath, codeCPUQuantity, "cpu %s", err.Error()))
+		return 0, false
+	}
+	return millicores, true
+}
+
+// validateResourceMemory parses a limits/requests.memory field, appending
+// any parse diagnostic to diags, and reports whether the returned byte
+// value is usable for the requests<=limits comparison.
+func validateResourceMemory(memory string, src *Source, memoryPath []string, diags *[]Diagnostic) (int64, bool) {
+	if memory == "" {
+		return 0, false
+	}
+	bytes, err := parseQuantity(memory, unitMemory)
+	if err != nil {
+		*diags = append(*diags, newDiagnostic(src, memoryPath, codeMemoryQuantity, "memory %s", err.Error()))
+		return 0, false
+	}
+	return bytes, true
+}
+
+// validateCPU parses a cpu quantity (e.g. "500m", "2", "0.5") into
+// millicores.
+func validateCPU(cpu string) (int64, error) {
+	return parseQuantity(cpu, unitCPU)
+}
@@ -0,0 +1,18 @@
+package main
+
+import "testing"
+
+func TestValidateProbeSkipsUnconfiguredProbe(t *testing.T) {
+	diags := validateProbe(Probe{}, nil, []string{"spec", "containers", "0", "livenessProbe"})
+	if len(diags) != 0 {
+		t.Fatalf("validateProbe(zero-value) = %v, want no diagnostics", diags)
+	}
+}
+
+func TestValidateProbeRejectsOutOfRangePort(t *testing.T) {
+	probe := Probe{HTTPGet: HTTPGet{Path: "/healthz", Port: 0}}
+	diags := validateProbe(probe, nil, []string{"spec", "containers", "0", "livenessProbe"})
+	if len(diags) != 1 || diags[0].Code != codeProbeRange {
+		t.Fatalf("validateProbe(configured, bad port) = %v, want one %s diagnostic", diags, codeProbeRange)
+	}
+}
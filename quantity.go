@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// binarySuffixes are the power-of-1024 SI suffixes Kubernetes accepts for
+// memory quantities (e.g. "128Mi").
+var binarySuffixes = map[string]int64{
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+	"Pi": 1 << 50,
+	"Ei": 1 << 60,
+}
+
+// decimalSuffixes are the power-of-1000 SI suffixes Kubernetes accepts for
+// both CPU and memory quantities (e.g. "1500k", "2M").
+var decimalSuffixes = map[string]int64{
+	"k": 1_000,
+	"M": 1_000_000,
+	"G": 1_000_000_000,
+	"T": 1_000_000_000_000,
+	"P": 1_000_000_000_000_000,
+	"E": 1_000_000_000_000_000_000,
+}
+
+// quantityUnit distinguishes what a bare, suffix-less decimal means, since
+// CPU and memory disagree: "2" is 2 cores (2000 millicores) for CPU but 2
+// bytes for memory.
+type quantityUnit int
+
+const (
+	unitCPU quantityUnit = iota
+	unitMemory
+)
+
+// parseQuantity parses a Kubernetes resource.Quantity-style string into its
+// base value: for CPU this is millicores, for memory this is bytes. unit
+// picks how a bare, suffix-less decimal is interpreted. It rejects anything
+// that isn't a positive quantity.
+func parseQuantity(s string, unit quantityUnit) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("quantity must not be empty")
+	}
+
+	// Millicores, e.g. "500m".
+	if strings.HasSuffix(s, "m") {
+		numeric := strings.TrimSuffix(s, "m")
+		value, err := strconv.ParseInt(numeric, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid millicore value %q", s)
+		}
+		return validatePositive(value, s)
+	}
+
+	for suffix, multiplier := range binarySuffixes {
+		if strings.HasSuffix(s, suffix) {
+			numeric := strings.TrimSuffix(s, suffix)
+			value, err := strconv.ParseFloat(numeric, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid quantity %q", s)
+			}
+			return validatePositive(int64(value*float64(multiplier)), s)
+		}
+	}
+
+	for suffix, multiplier := range decimalSuffixes {
+		if strings.HasSuffix(s, suffix) {
+			numeric := strings.TrimSuffix(s, suffix)
+			value, err := strconv.ParseFloat(numeric, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid quantity %q", s)
+			}
+			return validatePositive(int64(value*float64(multiplier)), s)
+		}
+	}
+
+	// Plain decimal, e.g. "2" or "0.5". For CPU that's cores -> millicores;
+	// for memory it's already bytes.
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	if unit == unitCPU {
+		return validatePositive(int64(value*1000), s)
+	}
+	return validatePositive(int64(value), s)
+}
+
+func validatePositive(value int64, original string) (int64, error) {
+	if value <= 0 {
+		return 0, fmt.Errorf("quantity %q must be positive", original)
+	}
+	return value, nil
+}
+
+// quantityString coerces the interface{} the YAML decoder produces for a
+// scalar field (int, float64 or string all parse as valid YAML) into its
+// string form so parseQuantity has one input shape to deal with.
+func quantityString(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case int:
+		return strconv.Itoa(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import "testing"
+
+func TestParseQuantityCPU(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{name: "millicores", in: "500m", want: 500},
+		{name: "bare cores", in: "2", want: 2000},
+		{name: "fractional cores", in: "0.5", want: 500},
+		{name: "decimal suffix", in: "1500m", want: 1500},
+		{name: "empty", in: "", wantErr: true},
+		{name: "zero", in: "0", wantErr: true},
+		{name: "negative", in: "-1", wantErr: true},
+		{name: "garbage", in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQuantity(tt.in, unitCPU)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseQuantity(%q, unitCPU) = %d, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQuantity(%q, unitCPU) returned unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("parseQuantity(%q, unitCPU) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQuantityMemory(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{name: "bare bytes", in: "200000000", want: 200000000},
+		{name: "binary suffix", in: "128Mi", want: 128 << 20},
+		{name: "decimal suffix", in: "2G", want: 2_000_000_000},
+		{name: "millicore-shaped suffix", in: "500m", want: 500},
+		{name: "empty", in: "", wantErr: true},
+		{name: "zero", in: "0", wantErr: true},
+		{name: "garbage", in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseQuantity(tt.in, unitMemory)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseQuantity(%q, unitMemory) = %d, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseQuantity(%q, unitMemory) returned unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Fatalf("parseQuantity(%q, unitMemory) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateResourcesMemoryRequestsDoesNotExceedLimits(t *testing.T) {
+	// A bare-decimal memory value (e.g. "200000000" bytes) must not be
+	// misread as cores and multiplied by 1000, which would make a legal
+	// requests<=limits pair look inverted.
+	resources := Resource{
+		Requests: ResourceLimits{Memory: "200000000"},
+		Limits:   ResourceLimits{Memory: "300000000"},
+	}
+
+	diags := validateResources(resources, nil, []string{"spec", "containers", "0", "resources"})
+	for _, d := range diags {
+		if d.Code == codeMemInvariant {
+			t.Fatalf("unexpected %s diagnostic for in-range memory requests/limits: %s", codeMemInvariant, d.Message)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// renderText prints one "file:line:col: [code] message (path)" line per
+// diagnostic, the tool's original human-readable format. path includes the
+// document[i] index so a multi-document stream's diagnostics stay
+// distinguishable even when two documents share a line/col (e.g. both
+// reporting "file:1:1" for a missing APIVersion).
+func renderText(w io.Writer, diags []Diagnostic) {
+	for _, d := range diags {
+		fmt.Fprintf(w, "%s:%d:%d: [%s] %s (%s)\n", d.File, d.Line, d.Column, d.Code, d.Message, d.Path)
+	}
+}
+
+func renderJSON(w io.Writer, diags []Diagnostic) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diags)
+}
+
+// SARIF 2.1.0 types, kept minimal to the fields this tool actually
+// populates. See https://docs.oasis-open.org/sarif/sarif/v2.1.0/.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string    `json:"id"`
+	ShortDescription sarifText `json:"shortDescription"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+// sarifLogicalLocation carries the diagnostic's document[i].field path, so
+// CI tooling consuming SARIF can tell apart two diagnostics that share a
+// physical file:line:col (e.g. the same missing field in two documents of a
+// multi-document stream).
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+func renderSARIF(w io.Writer, diags []Diagnostic) error {
+	rules := make(map[string]struct{})
+	var orderedRules []sarifRule
+	var results []sarifResult
+
+	for _, d := range diags {
+		if _, ok := rules[d.Code]; !ok {
+			rules[d.Code] = struct{}{}
+			orderedRules = append(orderedRules, sarifRule{ID: d.Code, ShortDescription: sarifText{Text: d.Message}})
+		}
+
+		results = append(results, sarifResult{
+			RuleID:  d.Code,
+			Level:   sarifLevel(d.Severity),
+			Message: sarifText{Text: d.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: d.File},
+					Region:           sarifRegion{StartLine: d.Line, StartColumn: d.Column},
+				},
+				LogicalLocations: []sarifLogicalLocation{{FullyQualifiedName: d.Path}},
+			}},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:  "podyaml-validate",
+				Rules: orderedRules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+func sarifLevel(severity string) string {
+	if severity == "" {
+		return "error"
+	}
+	return severity
+}
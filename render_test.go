@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func testDiagnostics() []Diagnostic {
+	return []Diagnostic{
+		{
+			File:     "pod.yaml",
+			Line:     3,
+			Column:   5,
+			Severity: "error",
+			Code:     codePodAPIVersion,
+			Message:  "APIVersion must be v1",
+			Path:     "document[0].APIVersion",
+		},
+		{
+			File:     "pod.yaml",
+			Line:     7,
+			Column:   9,
+			Severity: "error",
+			Code:     codePortRange,
+			Message:  "containerPort value out of range",
+			Path:     "document[0].spec.containers.0.ports.0.containerPort",
+		},
+	}
+}
+
+func TestRenderJSONRoundTrips(t *testing.T) {
+	want := testDiagnostics()
+
+	var buf bytes.Buffer
+	if err := renderJSON(&buf, want); err != nil {
+		t.Fatalf("renderJSON: %v", err)
+	}
+
+	var got []Diagnostic
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal rendered output: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d diagnostics, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("diagnostic %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRenderSARIFStructure(t *testing.T) {
+	diags := testDiagnostics()
+
+	var buf bytes.Buffer
+	if err := renderSARIF(&buf, diags); err != nil {
+		t.Fatalf("renderSARIF: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("json.Unmarshal rendered output: %v", err)
+	}
+
+	if log.Version != "2.1.0" {
+		t.Errorf("log.Version = %q, want 2.1.0", log.Version)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("len(log.Runs) = %d, want 1", len(log.Runs))
+	}
+
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(diags) {
+		t.Errorf("len(rules) = %d, want %d (one per distinct code)", len(run.Tool.Driver.Rules), len(diags))
+	}
+	if len(run.Results) != len(diags) {
+		t.Fatalf("len(results) = %d, want %d", len(run.Results), len(diags))
+	}
+
+	for i, d := range diags {
+		result := run.Results[i]
+		if result.RuleID != d.Code {
+			t.Errorf("results[%d].RuleID = %q, want %q", i, result.RuleID, d.Code)
+		}
+		if result.Level != d.Severity {
+			t.Errorf("results[%d].Level = %q, want %q", i, result.Level, d.Severity)
+		}
+
+		loc := result.Locations[0]
+		if loc.PhysicalLocation.ArtifactLocation.URI != d.File {
+			t.Errorf("results[%d] URI = %q, want %q", i, loc.PhysicalLocation.ArtifactLocation.URI, d.File)
+		}
+		if loc.PhysicalLocation.Region.StartLine != d.Line || loc.PhysicalLocation.Region.StartColumn != d.Column {
+			t.Errorf("results[%d] region = %d:%d, want %d:%d", i,
+				loc.PhysicalLocation.Region.StartLine, loc.PhysicalLocation.Region.StartColumn, d.Line, d.Column)
+		}
+		if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].FullyQualifiedName != d.Path {
+			t.Errorf("results[%d] logicalLocations = %v, want fullyQualifiedName %q", i, loc.LogicalLocations, d.Path)
+		}
+	}
+}
+
+func TestSarifLevelDefaultsToError(t *testing.T) {
+	if got := sarifLevel(""); got != "error" {
+		t.Errorf("sarifLevel(\"\") = %q, want %q", got, "error")
+	}
+	if got := sarifLevel("warning"); got != "warning" {
+		t.Errorf("sarifLevel(\"warning\") = %q, want %q", got, "warning")
+	}
+}
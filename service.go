@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Service struct {
+	APIVersion string      `yaml:"APIVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   Metadata    `yaml:"metadata"`
+	Spec       ServiceSpec `yaml:"spec"`
+}
+
+type ServiceSpec struct {
+	Type     string            `yaml:"type,omitempty"`
+	Selector map[string]string `yaml:"selector,omitempty"`
+	Ports    []ServicePort     `yaml:"ports"`
+}
+
+type ServicePort struct {
+	Name       string `yaml:"name,omitempty"`
+	Protocol   string `yaml:"protocol,omitempty"`
+	Port       int    `yaml:"port"`
+	TargetPort int    `yaml:"targetPort,omitempty"`
+}
+
+// Validate implements Document.
+func (s *Service) Validate(node *yaml.Node) []Diagnostic {
+	return decodeAndValidate(node, func(src *Source) []Diagnostic {
+		var diags []Diagnostic
+
+		if s.APIVersion == "" {
+			diags = append(diags, newDiagnostic(src, []string{"APIVersion"}, codePodAPIVersion, "APIVersion is required"))
+		}
+
+		if s.Kind != "Service" {
+			diags = append(diags, newDiagnostic(src, []string{"kind"}, codePodKind, "kind must be Service"))
+		}
+
+		if len(s.Metadata.Name) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"metadata", "name"}, codeMetadataName, "name is required"))
+		}
+
+		portsPath := []string{"spec", "ports"}
+		if len(s.Spec.Ports) == 0 {
+			diags = append(diags, newDiagnostic(src, []string{"spec"}, codeServicePorts, "spec.ports is required"))
+		}
+
+		for i, port := range s.Spec.Ports {
+			if port.Port <= 0 || port.Port > 65535 {
+				diags = append(diags, newDiagnostic(src, joinPath(portsPath, strconv.Itoa(i), "port"), codeServicePort, "port value out of range"))
+			}
+		}
+
+		return diags
+	})
+}
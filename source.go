@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source wraps the *yaml.Node tree for a single document so validators can
+// look up the originating line/column of any field they reject, instead of
+// scanning the raw text for the first line containing the field name.
+type Source struct {
+	root *yaml.Node
+}
+
+// newSource wraps node, the Node for a single document produced by
+// splitDocuments. Its Line/Column fields are already relative to the whole
+// stream, so callers don't need to adjust for the document's position
+// within a multi-document file.
+func newSource(node *yaml.Node) *Source {
+	return &Source{root: node}
+}
+
+// nodeFor walks the document from its root mapping node following path,
+// where each segment is either a mapping key or, for a sequence node, a
+// base-10 index. It returns nil if path doesn't resolve to anything in the
+// document (e.g. the field was omitted).
+func (s *Source) nodeFor(path ...string) *yaml.Node {
+	if s == nil || s.root == nil {
+		return nil
+	}
+
+	node := s.root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	for _, seg := range path {
+		node = descend(node, seg)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+func descend(node *yaml.Node, seg string) *yaml.Node {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == seg {
+				return node.Content[i+1]
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		idx, err := strconv.Atoi(seg)
+		if err != nil || idx < 0 || idx >= len(node.Content) {
+			return nil
+		}
+		return node.Content[idx]
+	default:
+		return nil
+	}
+}
+
+// lineCol resolves path to a line/column, falling back to the nearest
+// ancestor that does resolve (e.g. pointing at the container when one of
+// its fields is missing entirely). It returns (-1, -1) if even the root
+// doesn't resolve.
+func (s *Source) lineCol(path ...string) (int, int) {
+	for i := len(path); i >= 0; i-- {
+		if node := s.nodeFor(path[:i]...); node != nil {
+			return node.Line, node.Column
+		}
+	}
+	return -1, -1
+}
+
+// joinPath returns a fresh slice with extra appended to base, so callers
+// building sibling paths from a shared prefix (e.g. per-container paths in a
+// loop) don't alias each other's backing arrays.
+func joinPath(base []string, extra ...string) []string {
+	joined := make([]string, 0, len(base)+len(extra))
+	joined = append(joined, base...)
+	joined = append(joined, extra...)
+	return joined
+}